@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Supported values for every verifier's --output flag.
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputSARIF = "sarif"
+)
+
+// validateOutputFormat rejects any --output value the verifiers don't know how to render.
+func validateOutputFormat(output string) error {
+	switch output {
+	case outputText, outputJSON, outputSARIF:
+		return nil
+	default:
+		return fmt.Errorf("--output must be one of %s, %s, or %s, got %q", outputText, outputJSON, outputSARIF, output)
+	}
+}
+
+// featureSetRelation is a containment relationship a featuregate report is built against:
+// every featuregate Superset enables must also be enabled (and not disabled) in Subset.
+type featureSetRelation struct {
+	Superset string
+	Subset   string
+}
+
+// featureGateReport is the structured, per-(ClusterProfile, FeatureSet) form of a verifier's
+// findings, used to render --output=json|sarif so CI can consume violations without parsing
+// the free-text ERROR: messages the text output writes to ErrOut.
+type featureGateReport struct {
+	Profile      string   `json:"profile"`
+	FeatureSet   string   `json:"featureset"`
+	MissingGates []string `json:"missingGates,omitempty"`
+	ExtraGates   []string `json:"extraGates,omitempty"`
+	Conflicts    []string `json:"conflicts,omitempty"`
+	manifestPath string
+}
+
+// buildFeatureGateReports computes, for every profile in allowedProfiles (or every profile found
+// in the manifests, if allowedProfiles is empty), one featureGateReport per featureset that has
+// a violation: featuregates missing relative to each relation's superset ("missingGates"),
+// featuregates a relation's superset enables but this featureset explicitly disables, featuregates
+// a conflictPairs partner enables but this featureset explicitly disables, and featuregates this
+// featureset both enables and disables (all three "conflicts"), and featuregates known elsewhere
+// among the featuresets this command cares about but not mentioned here at all ("extraGates").
+//
+// Only featuresets referenced by relations or conflictPairs are considered: a command must not
+// report integrity violations that live purely in a featureset it has no business checking, and
+// --output=json|sarif must report exactly the same violations as --output=text for that command.
+// The text-mode equivalent, verifyFeatureGateSetIntegrity, takes the same relevantFeatureSets
+// restriction for the same reason — keep the two in sync when either one's scoping changes.
+func buildFeatureGateReports(featureSetsByProfile map[string]map[string]featureGateSet, allowedProfiles sets.String, relations []featureSetRelation, conflictPairs [][2]string) []featureGateReport {
+	reports := []featureGateReport{}
+
+	relevantFeatureSets := sets.NewString()
+	for _, relation := range relations {
+		relevantFeatureSets.Insert(relation.Superset, relation.Subset)
+	}
+	for _, pair := range conflictPairs {
+		relevantFeatureSets.Insert(pair[0], pair[1])
+	}
+
+	profiles := make([]string, 0, len(featureSetsByProfile))
+	for profile := range featureSetsByProfile {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	for _, profile := range profiles {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
+			continue
+		}
+		featureSets := featureSetsByProfile[profile]
+
+		byFeatureSet := map[string]*featureGateReport{}
+		reportFor := func(featureSet string) *featureGateReport {
+			if r, ok := byFeatureSet[featureSet]; ok {
+				return r
+			}
+			r := &featureGateReport{Profile: profile, FeatureSet: featureSet, manifestPath: featureSets[featureSet].ManifestPath}
+			byFeatureSet[featureSet] = r
+			return r
+		}
+
+		allKnownGates := sets.NewString()
+		for featureSet, set := range featureSets {
+			if !relevantFeatureSets.Has(featureSet) {
+				continue
+			}
+			allKnownGates = allKnownGates.Union(set.Enabled).Union(set.Disabled)
+		}
+		for featureSet, set := range featureSets {
+			if !relevantFeatureSets.Has(featureSet) {
+				continue
+			}
+			if overlap := set.Enabled.Intersection(set.Disabled); overlap.Len() > 0 {
+				reportFor(featureSet).Conflicts = append(reportFor(featureSet).Conflicts, overlap.List()...)
+			}
+			if unmentioned := allKnownGates.Difference(set.Enabled).Difference(set.Disabled); unmentioned.Len() > 0 {
+				reportFor(featureSet).ExtraGates = append(reportFor(featureSet).ExtraGates, unmentioned.List()...)
+			}
+		}
+
+		for _, relation := range relations {
+			supersetSet, hasSuperset := featureSets[relation.Superset]
+			subsetSet, hasSubset := featureSets[relation.Subset]
+			if !hasSuperset || !hasSubset {
+				continue
+			}
+
+			if missing := supersetSet.Enabled.Difference(subsetSet.Enabled); missing.Len() > 0 {
+				reportFor(relation.Subset).MissingGates = append(reportFor(relation.Subset).MissingGates, missing.List()...)
+			}
+			if conflicting := supersetSet.Enabled.Intersection(subsetSet.Disabled); conflicting.Len() > 0 {
+				reportFor(relation.Subset).Conflicts = append(reportFor(relation.Subset).Conflicts, conflicting.List()...)
+			}
+		}
+
+		for _, pair := range conflictPairs {
+			firstSet, hasFirst := featureSets[pair[0]]
+			secondSet, hasSecond := featureSets[pair[1]]
+			if !hasFirst || !hasSecond {
+				continue
+			}
+
+			if conflicting := firstSet.Enabled.Intersection(secondSet.Disabled); conflicting.Len() > 0 {
+				reportFor(pair[1]).Conflicts = append(reportFor(pair[1]).Conflicts, conflicting.List()...)
+			}
+			if conflicting := secondSet.Enabled.Intersection(firstSet.Disabled); conflicting.Len() > 0 {
+				reportFor(pair[0]).Conflicts = append(reportFor(pair[0]).Conflicts, conflicting.List()...)
+			}
+		}
+
+		featureSetNames := make([]string, 0, len(byFeatureSet))
+		for featureSet := range byFeatureSet {
+			featureSetNames = append(featureSetNames, featureSet)
+		}
+		sort.Strings(featureSetNames)
+
+		for _, featureSet := range featureSetNames {
+			report := byFeatureSet[featureSet]
+			sort.Strings(report.MissingGates)
+			sort.Strings(report.ExtraGates)
+			sort.Strings(report.Conflicts)
+			if len(report.MissingGates) == 0 && len(report.ExtraGates) == 0 && len(report.Conflicts) == 0 {
+				continue
+			}
+			reports = append(reports, *report)
+		}
+	}
+
+	return reports
+}
+
+// renderFeatureGateReports writes reports to out in the given format and returns a non-nil error
+// if reports is non-empty, so callers can still use it to drive a non-zero CI exit code.
+func renderFeatureGateReports(out io.Writer, format string, reports []featureGateReport) error {
+	switch format {
+	case outputJSON:
+		if err := renderFeatureGateReportsJSON(out, reports); err != nil {
+			return err
+		}
+	case outputSARIF:
+		if err := renderFeatureGateReportsSARIF(out, reports); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported --output %q", format)
+	}
+
+	if len(reports) > 0 {
+		return fmt.Errorf("featuregate verification failed")
+	}
+	return nil
+}
+
+func renderFeatureGateReportsJSON(out io.Writer, reports []featureGateReport) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(reports)
+}
+
+// sarifReport is a minimal SARIF 2.1.0 document: one run, one result per missing featuregate.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func renderFeatureGateReportsSARIF(out io.Writer, reports []featureGateReport) error {
+	ruleIDs := sets.NewString()
+	results := []sarifResult{}
+
+	for _, report := range reports {
+		ruleID := report.FeatureSet + "FeatureGateMissing"
+		ruleIDs.Insert(ruleID)
+
+		for _, gate := range report.MissingGates {
+			results = append(results, sarifResult{
+				RuleID:  ruleID,
+				Message: sarifMessage{Text: fmt.Sprintf("ClusterProfile %q: featuregate %q is missing from the %q featureset", report.Profile, gate, report.FeatureSet)},
+				Locations: []sarifLocation{
+					{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: report.manifestPath}}},
+				},
+			})
+		}
+	}
+
+	rules := make([]sarifRule, 0, ruleIDs.Len())
+	for _, ruleID := range ruleIDs.List() {
+		rules = append(rules, sarifRule{ID: ruleID})
+	}
+
+	report := sarifReport{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/main/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: sarifDriver{Name: "verify-featuregates", Rules: rules}},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// featureGateRuntimeConfigGroupVersion maps a featuregate name to the kube-apiserver
+// "--runtime-config" API group/version it toggles. Every featuregate that enables or
+// disables an API group/version must have an entry here, or render-runtime-config
+// cannot keep the apiserver's runtime-config in lockstep with the featuregate manifests.
+var featureGateRuntimeConfigGroupVersion = map[string]string{
+	"ValidatingAdmissionPolicy": "admissionregistration.k8s.io/v1beta1",
+}
+
+type renderRuntimeConfigOptions struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+
+	FeatureSetManifestDir string
+	ValidateOnly          bool
+}
+
+func newRenderRuntimeConfigOptions(in io.Reader, out, errOut io.Writer) *renderRuntimeConfigOptions {
+	return &renderRuntimeConfigOptions{
+		In:                    in,
+		Out:                   out,
+		ErrOut:                errOut,
+		FeatureSetManifestDir: filepath.Join("payload-manifests", "featuregates"),
+	}
+}
+
+func NewRenderRuntimeConfigCommand(in io.Reader, out, errOut io.Writer) *cobra.Command {
+	o := newRenderRuntimeConfigOptions(in, out, errOut)
+
+	cmd := &cobra.Command{
+		Use:   "render-runtime-config",
+		Short: "render-runtime-config emits the kube-apiserver runtime-config flags implied by the featuregate manifests",
+		Long: `This command reads the featuregate manifests and, for every (ClusterProfile, FeatureSet)
+tuple, renders the set of "--runtime-config" API group/version toggles that kube-apiserver-operator's
+config observer must pass to kube-apiserver to keep the API surface in lockstep with the enabled
+featuregates. Use --validate to fail when a manifest enables a featuregate with no known runtime-config
+mapping, so new gates cannot silently break API enablement.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(ctx)
+		},
+	}
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewRenderRuntimeConfigCommand(os.Stdin, os.Stdout, os.Stderr))
+}
+
+func (o *renderRuntimeConfigOptions) Validate() error {
+	if len(o.FeatureSetManifestDir) == 0 {
+		return fmt.Errorf("--featureset-manifest-path is required")
+	}
+	if _, err := os.ReadDir(o.FeatureSetManifestDir); err != nil {
+		return fmt.Errorf("--featureset-manifest-path cannot be read: %w", err)
+	}
+	return nil
+}
+
+func (o *renderRuntimeConfigOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.FeatureSetManifestDir, "featureset-manifest-path", o.FeatureSetManifestDir, "path to directory containing the FeatureGate YAMLs for each FeatureSet,ClusterProfile tuple.")
+	flags.BoolVar(&o.ValidateOnly, "validate", o.ValidateOnly, "fail if a manifest enables a featuregate with no known runtime-config mapping, instead of rendering.")
+}
+
+func (o *renderRuntimeConfigOptions) Run(ctx context.Context) error {
+	featureSetsByProfile, err := readFeatureGateManifests(o.FeatureSetManifestDir)
+	if err != nil {
+		return err
+	}
+
+	profiles := make([]string, 0, len(featureSetsByProfile))
+	for profile := range featureSetsByProfile {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	unknownGates := []string{}
+	for _, profile := range profiles {
+		featureSets := featureSetsByProfile[profile]
+
+		featureSetNames := make([]string, 0, len(featureSets))
+		for featureSet := range featureSets {
+			featureSetNames = append(featureSetNames, featureSet)
+		}
+		sort.Strings(featureSetNames)
+
+		for _, featureSet := range featureSetNames {
+			toggles, unknown := runtimeConfigTogglesForGates(featureSets[featureSet].Enabled)
+			unknownGates = append(unknownGates, unknown...)
+
+			if o.ValidateOnly {
+				continue
+			}
+
+			fmt.Fprintf(o.Out, "# ClusterProfile=%s FeatureSet=%s\n", profile, featureSet)
+			fmt.Fprintln(o.Out, "apiServerArguments:")
+			fmt.Fprintln(o.Out, "  runtime-config:")
+			for _, toggle := range toggles {
+				fmt.Fprintf(o.Out, "  - %s\n", toggle)
+			}
+			fmt.Fprintln(o.Out)
+		}
+	}
+
+	if o.ValidateOnly && len(unknownGates) > 0 {
+		sort.Strings(unknownGates)
+		return fmt.Errorf("no runtime-config mapping for featuregate(s): %s", strings.Join(sets.NewString(unknownGates...).List(), ", "))
+	}
+
+	return nil
+}
+
+// runtimeConfigTogglesForGates computes the "group/version=true|false" runtime-config
+// toggle for every known featuregate, given the set of featuregates enabled by a manifest.
+// Gates with no known runtime-config mapping are returned separately so callers can
+// decide whether that is fatal.
+func runtimeConfigTogglesForGates(enabledGates sets.String) (toggles []string, unknownGates []string) {
+	gateNames := make([]string, 0, len(featureGateRuntimeConfigGroupVersion))
+	for gate := range featureGateRuntimeConfigGroupVersion {
+		gateNames = append(gateNames, gate)
+	}
+	sort.Strings(gateNames)
+
+	for _, gate := range gateNames {
+		groupVersion := featureGateRuntimeConfigGroupVersion[gate]
+		toggles = append(toggles, fmt.Sprintf("%s=%t", groupVersion, enabledGates.Has(gate)))
+	}
+
+	for _, gate := range enabledGates.List() {
+		if _, known := featureGateRuntimeConfigGroupVersion[gate]; !known {
+			unknownGates = append(unknownGates, gate)
+		}
+	}
+
+	return toggles, unknownGates
+}
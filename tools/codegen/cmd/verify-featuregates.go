@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// featureSetContainmentChains enumerates the "must be a superset of" relationships that hold
+// across the OpenShift feature-set lattice, expressed as a chain from the smallest featureset
+// to the largest. Every featuregate enabled earlier in the chain must also be enabled later in it.
+var featureSetContainmentChains = [][]string{
+	{"Default", "TechPreviewNoUpgrade", "CustomNoUpgrade"},
+	{"Default", "DevPreviewNoUpgrade"},
+}
+
+// featureSetConflictPairs enumerates featureset pairs that, unlike featureSetContainmentChains,
+// have no containment relationship but must still never disagree on a gate: neither featureset
+// in a pair may explicitly disable a gate the other explicitly enables.
+var featureSetConflictPairs = [][2]string{
+	{"TechPreviewNoUpgrade", "DevPreviewNoUpgrade"},
+}
+
+type verifyPreviewFeatureGatesOptions struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+
+	FeatureSetManifestDir string
+	Profiles              []string
+	Output                string
+
+	// chain is the containment chain this instance checks, e.g.
+	// []string{"Default", "TechPreviewNoUpgrade", "CustomNoUpgrade"}.
+	chain []string
+}
+
+func newVerifyPreviewFeatureGatesOptions(in io.Reader, out, errOut io.Writer, chain []string) *verifyPreviewFeatureGatesOptions {
+	return &verifyPreviewFeatureGatesOptions{
+		In:                    in,
+		Out:                   out,
+		ErrOut:                errOut,
+		FeatureSetManifestDir: filepath.Join("payload-manifests", "featuregates"),
+		Output:                outputText,
+		chain:                 chain,
+	}
+}
+
+// NewVerifyTechPreviewFeatureGatesCommand verifies Default ⊆ TechPreviewNoUpgrade ⊆ CustomNoUpgrade.
+func NewVerifyTechPreviewFeatureGatesCommand(in io.Reader, out, errOut io.Writer) *cobra.Command {
+	o := newVerifyPreviewFeatureGatesOptions(in, out, errOut, []string{"Default", "TechPreviewNoUpgrade", "CustomNoUpgrade"})
+
+	cmd := &cobra.Command{
+		Use:   "verify-techpreview-featuregates",
+		Short: "verify-techpreview-featuregates verifies that TechPreviewNoUpgrade and CustomNoUpgrade are supersets of Default",
+		Long: `This verifier ensures that every featuregate enabled in Default is also enabled in
+TechPreviewNoUpgrade, and that every featuregate enabled in TechPreviewNoUpgrade is also enabled
+in CustomNoUpgrade, matching the containment relationships in the OpenShift feature-set lattice.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(ctx)
+		},
+	}
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// NewVerifyDevPreviewFeatureGatesCommand verifies Default ⊆ DevPreviewNoUpgrade.
+func NewVerifyDevPreviewFeatureGatesCommand(in io.Reader, out, errOut io.Writer) *cobra.Command {
+	o := newVerifyPreviewFeatureGatesOptions(in, out, errOut, []string{"Default", "DevPreviewNoUpgrade"})
+
+	cmd := &cobra.Command{
+		Use:   "verify-devpreview-featuregates",
+		Short: "verify-devpreview-featuregates verifies that DevPreviewNoUpgrade is a superset of Default",
+		Long: `This verifier ensures that every featuregate enabled in Default is also enabled in
+DevPreviewNoUpgrade, matching the containment relationship in the OpenShift feature-set lattice.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(ctx)
+		},
+	}
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewVerifyTechPreviewFeatureGatesCommand(os.Stdin, os.Stdout, os.Stderr))
+	rootCmd.AddCommand(NewVerifyDevPreviewFeatureGatesCommand(os.Stdin, os.Stdout, os.Stderr))
+	rootCmd.AddCommand(NewVerifyFeatureGatesCommand(os.Stdin, os.Stdout, os.Stderr))
+}
+
+func (o *verifyPreviewFeatureGatesOptions) Validate() error {
+	if len(o.FeatureSetManifestDir) == 0 {
+		return fmt.Errorf("--featureset-manifest-path is required")
+	}
+	if _, err := os.ReadDir(o.FeatureSetManifestDir); err != nil {
+		return fmt.Errorf("--featureset-manifest-path cannot be read: %w", err)
+	}
+	if err := validateOutputFormat(o.Output); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *verifyPreviewFeatureGatesOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.FeatureSetManifestDir, "featureset-manifest-path", o.FeatureSetManifestDir, "path to directory containing the FeatureGate YAMLs for each FeatureSet,ClusterProfile tuple.")
+	flags.StringSliceVar(&o.Profiles, "profiles", o.Profiles, "restrict verification to these ClusterProfiles. Defaults to every profile found in the manifests.")
+	flags.StringVar(&o.Output, "output", o.Output, "output format: text, json, or sarif.")
+}
+
+func (o *verifyPreviewFeatureGatesOptions) Run(ctx context.Context) error {
+	featureSetsByProfile, err := readFeatureGateManifests(o.FeatureSetManifestDir)
+	if err != nil {
+		return err
+	}
+
+	allowedProfiles := sets.NewString(o.Profiles...)
+
+	if o.Output != outputText {
+		reports := buildFeatureGateReports(featureSetsByProfile, allowedProfiles, chainRelations(o.chain), featureSetConflictPairs)
+		return renderFeatureGateReports(o.Out, o.Output, reports)
+	}
+
+	relevantFeatureSets := sets.NewString(o.chain...)
+	for _, pair := range featureSetConflictPairs {
+		relevantFeatureSets.Insert(pair[0], pair[1])
+	}
+
+	allErrors := verifyFeatureSetChain(featureSetsByProfile, allowedProfiles, o.chain)
+	allErrors = append(allErrors, verifyFeatureSetConflicts(featureSetsByProfile, allowedProfiles, featureSetConflictPairs)...)
+	for profile, featureSets := range featureSetsByProfile {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
+			continue
+		}
+		allErrors = append(allErrors, verifyFeatureGateSetIntegrity(profile, featureSets, relevantFeatureSets)...)
+	}
+
+	if len(allErrors) > 0 {
+		fmt.Fprintln(o.ErrOut, strings.Join(allErrors, "\n\n"))
+		return fmt.Errorf("featuregate verification failed")
+	}
+
+	return nil
+}
+
+// verifyFeatureSetChain checks, for every profile in allowedProfiles (or every profile found in the
+// manifests, if allowedProfiles is empty), that each featureset in chain is a superset of the one
+// before it. Profiles missing one of the chain's featuresets are skipped for that link.
+func verifyFeatureSetChain(featureSetsByProfile map[string]map[string]featureGateSet, allowedProfiles sets.String, chain []string) []string {
+	allErrors := []string{}
+
+	profiles := make([]string, 0, len(featureSetsByProfile))
+	for profile := range featureSetsByProfile {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	for _, profile := range profiles {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
+			continue
+		}
+		featureSets := featureSetsByProfile[profile]
+
+		for i := 1; i < len(chain); i++ {
+			lowerFeatureSet, higherFeatureSet := chain[i-1], chain[i]
+
+			lowerSet, hasLower := featureSets[lowerFeatureSet]
+			higherSet, hasHigher := featureSets[higherFeatureSet]
+			if !hasLower || !hasHigher {
+				continue
+			}
+
+			if missing := lowerSet.Enabled.Difference(higherSet.Enabled); missing.Len() > 0 {
+				allErrors = append(allErrors, formatMissingGatesError(profile, lowerFeatureSet, higherFeatureSet, missing))
+			}
+
+			if conflicting := lowerSet.Enabled.Intersection(higherSet.Disabled); conflicting.Len() > 0 {
+				allErrors = append(allErrors, formatConflictingDisableError(profile, lowerFeatureSet, higherFeatureSet, conflicting))
+			}
+		}
+	}
+
+	return allErrors
+}
+
+// verifyFeatureSetConflicts checks, for every profile in allowedProfiles (or every profile found
+// in the manifests, if allowedProfiles is empty), that neither featureset in each pair explicitly
+// disables a gate the other featureset in the pair explicitly enables.
+func verifyFeatureSetConflicts(featureSetsByProfile map[string]map[string]featureGateSet, allowedProfiles sets.String, pairs [][2]string) []string {
+	allErrors := []string{}
+
+	profiles := make([]string, 0, len(featureSetsByProfile))
+	for profile := range featureSetsByProfile {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	for _, profile := range profiles {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
+			continue
+		}
+		featureSets := featureSetsByProfile[profile]
+
+		for _, pair := range pairs {
+			firstSet, hasFirst := featureSets[pair[0]]
+			secondSet, hasSecond := featureSets[pair[1]]
+			if !hasFirst || !hasSecond {
+				continue
+			}
+
+			if conflicting := firstSet.Enabled.Intersection(secondSet.Disabled); conflicting.Len() > 0 {
+				allErrors = append(allErrors, formatConflictingDisableError(profile, pair[0], pair[1], conflicting))
+			}
+			if conflicting := secondSet.Enabled.Intersection(firstSet.Disabled); conflicting.Len() > 0 {
+				allErrors = append(allErrors, formatConflictingDisableError(profile, pair[1], pair[0], conflicting))
+			}
+		}
+	}
+
+	return allErrors
+}
+
+// NewVerifyFeatureGatesCommand runs every featuregate consistency check (OKD, TechPreview,
+// DevPreview) so CI has a single entrypoint instead of wiring up each verifier separately.
+func NewVerifyFeatureGatesCommand(in io.Reader, out, errOut io.Writer) *cobra.Command {
+	o := newVerifyOKDFeatureGatesOptions(in, out, errOut)
+
+	cmd := &cobra.Command{
+		Use:   "verify-featuregates",
+		Short: "verify-featuregates runs all featuregate consistency checks (OKD, TechPreview, DevPreview)",
+		Long: `This command runs every featuregate containment verifier against the same set of
+manifests: Default must be a subset of OKD, TechPreviewNoUpgrade, CustomNoUpgrade, and
+DevPreviewNoUpgrade. It reports every violation across all verifiers before failing, so a single
+CI job surfaces the complete picture instead of one verifier at a time.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.runAll(ctx)
+		},
+	}
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *verifyOKDFeatureGatesOptions) runAll(ctx context.Context) error {
+	featureSetsByProfile, err := readFeatureGateManifests(o.FeatureSetManifestDir)
+	if err != nil {
+		return err
+	}
+
+	allowedProfiles := sets.NewString(o.Profiles...)
+
+	if o.Output != outputText {
+		relations := append([]featureSetRelation{{Superset: "Default", Subset: "OKD"}}, allChainRelations()...)
+		reports := buildFeatureGateReports(featureSetsByProfile, allowedProfiles, relations, featureSetConflictPairs)
+		return renderFeatureGateReports(o.Out, o.Output, reports)
+	}
+
+	// The Default/OKD relation is checked as a chain so that the integrity check below runs once,
+	// over every featureset this command compares, as buildFeatureGateReports does.
+	relevantFeatureSets := sets.NewString("Default", "OKD")
+	allErrors := verifyFeatureSetChain(featureSetsByProfile, allowedProfiles, []string{"Default", "OKD"})
+	for _, chain := range featureSetContainmentChains {
+		relevantFeatureSets.Insert(chain...)
+		allErrors = append(allErrors, verifyFeatureSetChain(featureSetsByProfile, allowedProfiles, chain)...)
+	}
+	for _, pair := range featureSetConflictPairs {
+		relevantFeatureSets.Insert(pair[0], pair[1])
+	}
+	allErrors = append(allErrors, verifyFeatureSetConflicts(featureSetsByProfile, allowedProfiles, featureSetConflictPairs)...)
+	for profile, featureSets := range featureSetsByProfile {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
+			continue
+		}
+		allErrors = append(allErrors, verifyFeatureGateSetIntegrity(profile, featureSets, relevantFeatureSets)...)
+	}
+
+	if len(allErrors) > 0 {
+		fmt.Fprintln(o.ErrOut, strings.Join(allErrors, "\n\n"))
+		return fmt.Errorf("featuregate verification failed")
+	}
+
+	return nil
+}
+
+// chainRelations expands a containment chain (smallest to largest featureset) into the
+// consecutive (superset, subset) pairs buildFeatureGateReports checks.
+func chainRelations(chain []string) []featureSetRelation {
+	relations := make([]featureSetRelation, 0, len(chain)-1)
+	for i := 1; i < len(chain); i++ {
+		relations = append(relations, featureSetRelation{Superset: chain[i-1], Subset: chain[i]})
+	}
+	return relations
+}
+
+// allChainRelations expands every chain in featureSetContainmentChains into (superset, subset) pairs.
+func allChainRelations() []featureSetRelation {
+	relations := []featureSetRelation{}
+	for _, chain := range featureSetContainmentChains {
+		relations = append(relations, chainRelations(chain)...)
+	}
+	return relations
+}
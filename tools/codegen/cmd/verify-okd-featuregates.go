@@ -22,6 +22,8 @@ type verifyOKDFeatureGatesOptions struct {
 	ErrOut io.Writer
 
 	FeatureSetManifestDir string
+	Profiles              []string
+	Output                string
 }
 
 func newVerifyOKDFeatureGatesOptions(in io.Reader, out, errOut io.Writer) *verifyOKDFeatureGatesOptions {
@@ -30,6 +32,7 @@ func newVerifyOKDFeatureGatesOptions(in io.Reader, out, errOut io.Writer) *verif
 		Out:                   out,
 		ErrOut:                errOut,
 		FeatureSetManifestDir: filepath.Join("payload-manifests", "featuregates"),
+		Output:                outputText,
 	}
 }
 
@@ -66,11 +69,16 @@ func (o *verifyOKDFeatureGatesOptions) Validate() error {
 	if _, err := os.ReadDir(o.FeatureSetManifestDir); err != nil {
 		return fmt.Errorf("--featureset-manifest-path cannot be read: %w", err)
 	}
+	if err := validateOutputFormat(o.Output); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (o *verifyOKDFeatureGatesOptions) AddFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.FeatureSetManifestDir, "featureset-manifest-path", o.FeatureSetManifestDir, "path to directory containing the FeatureGate YAMLs for each FeatureSet,ClusterProfile tuple.")
+	flags.StringSliceVar(&o.Profiles, "profiles", o.Profiles, "restrict verification to these ClusterProfiles (e.g. SelfManagedHA,Hypershift). Defaults to every profile found in the manifests.")
+	flags.StringVar(&o.Output, "output", o.Output, "output format: text, json, or sarif.")
 }
 
 func (o *verifyOKDFeatureGatesOptions) Run(ctx context.Context) error {
@@ -80,52 +88,157 @@ func (o *verifyOKDFeatureGatesOptions) Run(ctx context.Context) error {
 		return err
 	}
 
+	if o.Output != outputText {
+		reports := buildFeatureGateReports(featureSetsByProfile, sets.NewString(o.Profiles...), []featureSetRelation{{Superset: "Default", Subset: "OKD"}}, nil)
+		return renderFeatureGateReports(o.Out, o.Output, reports)
+	}
+
+	allErrors := verifyOKDFeatureGates(featureSetsByProfile, sets.NewString(o.Profiles...))
+
+	if len(allErrors) > 0 {
+		fmt.Fprintln(o.ErrOut, strings.Join(allErrors, "\n\n"))
+		return fmt.Errorf("OKD featuregate verification failed")
+	}
+
+	return nil
+}
+
+// verifyOKDFeatureGates checks that OKD enables every featuregate Default enables and never
+// explicitly disables one, for every profile in allowedProfiles (or every profile found in the
+// manifests, if allowedProfiles is empty). It also checks the manifest integrity invariants that
+// apply regardless of which featuresets are being compared.
+func verifyOKDFeatureGates(featureSetsByProfile map[string]map[string]featureGateSet, allowedProfiles sets.String) []string {
 	allErrors := []string{}
 
-	// Check each cluster profile
 	for profile, featureSets := range featureSetsByProfile {
-		defaultGates, hasDefault := featureSets["Default"]
-		okdGates, hasOKD := featureSets["OKD"]
-
-		// If OKD doesn't exist for this profile, skip
-		if !hasOKD {
+		if allowedProfiles.Len() > 0 && !allowedProfiles.Has(profile) {
 			continue
 		}
 
-		// If Default doesn't exist for this profile, skip
-		if !hasDefault {
+		allErrors = append(allErrors, verifyFeatureGateSetIntegrity(profile, featureSets, sets.NewString("Default", "OKD"))...)
+
+		defaultSet, hasDefault := featureSets["Default"]
+		okdSet, hasOKD := featureSets["OKD"]
+
+		// If OKD or Default doesn't exist for this profile, skip
+		if !hasOKD || !hasDefault {
 			continue
 		}
 
-		// Check that all Default featuregates are in OKD
-		missingInOKD := defaultGates.Difference(okdGates)
+		// Check that all Default featuregates are enabled in OKD
+		if missingInOKD := defaultSet.Enabled.Difference(okdSet.Enabled); missingInOKD.Len() > 0 {
+			allErrors = append(allErrors, formatMissingGatesError(profile, "Default", "OKD", missingInOKD))
+		}
 
-		if missingInOKD.Len() > 0 {
-			missingList := missingInOKD.List()
-			sort.Strings(missingList)
+		// Check that OKD doesn't explicitly disable a featuregate Default enables
+		if conflicting := defaultSet.Enabled.Intersection(okdSet.Disabled); conflicting.Len() > 0 {
+			allErrors = append(allErrors, formatConflictingDisableError(profile, "Default", "OKD", conflicting))
+		}
+	}
+
+	return allErrors
+}
 
-			errorMsg := fmt.Sprintf(
-				"ERROR: ClusterProfile %q: OKD featureset is missing %d featuregate(s) that are enabled in Default:\n  - %s\n\nAll featuregates enabled in Default must also be enabled in OKD.",
-				profile,
-				missingInOKD.Len(),
-				strings.Join(missingList, "\n  - "),
-			)
-			allErrors = append(allErrors, errorMsg)
+// verifyFeatureGateSetIntegrity checks the invariants every manifest in a profile must satisfy
+// regardless of which featuresets are being compared against each other: no featureset may both
+// enable and disable the same gate, and every featureset must account for (enable or disable)
+// every gate known among relevantFeatureSets. Featuresets outside relevantFeatureSets (e.g.
+// TechPreviewNoUpgrade when a caller only cares about Default/OKD) are ignored entirely, so a
+// command's text output doesn't fail on gates that belong to a comparison it never makes.
+func verifyFeatureGateSetIntegrity(profile string, featureSets map[string]featureGateSet, relevantFeatureSets sets.String) []string {
+	allErrors := []string{}
+
+	allKnownGates := sets.NewString()
+	for featureSet, set := range featureSets {
+		if !relevantFeatureSets.Has(featureSet) {
+			continue
 		}
+		allKnownGates = allKnownGates.Union(set.Enabled).Union(set.Disabled)
 	}
 
-	if len(allErrors) > 0 {
-		fmt.Fprintln(o.ErrOut, strings.Join(allErrors, "\n\n"))
-		return fmt.Errorf("OKD featuregate verification failed")
+	featureSetNames := make([]string, 0, len(featureSets))
+	for featureSet := range featureSets {
+		if !relevantFeatureSets.Has(featureSet) {
+			continue
+		}
+		featureSetNames = append(featureSetNames, featureSet)
 	}
+	sort.Strings(featureSetNames)
+
+	for _, featureSet := range featureSetNames {
+		set := featureSets[featureSet]
+
+		if overlap := set.Enabled.Intersection(set.Disabled); overlap.Len() > 0 {
+			overlapList := overlap.List()
+			sort.Strings(overlapList)
+			allErrors = append(allErrors, fmt.Sprintf(
+				"ERROR: ClusterProfile %q: %s featureset both enables and disables %d featuregate(s):\n  - %s",
+				profile, featureSet, len(overlapList), strings.Join(overlapList, "\n  - "),
+			))
+		}
 
-	return nil
+		if missing := allKnownGates.Difference(set.Enabled).Difference(set.Disabled); missing.Len() > 0 {
+			missingList := missing.List()
+			sort.Strings(missingList)
+			allErrors = append(allErrors, fmt.Sprintf(
+				"ERROR: ClusterProfile %q: %s featureset does not enable or disable %d featuregate(s) known elsewhere in this profile:\n  - %s",
+				profile, featureSet, len(missingList), strings.Join(missingList, "\n  - "),
+			))
+		}
+	}
+
+	return allErrors
+}
+
+// formatMissingGatesError renders the standard violation message shared by every
+// featureset-containment verifier (OKD, TechPreview, DevPreview): supersetFeatureSet's
+// enabled featuregates must all be enabled in subsetFeatureSet too.
+func formatMissingGatesError(profile, supersetFeatureSet, subsetFeatureSet string, missing sets.String) string {
+	missingList := missing.List()
+	sort.Strings(missingList)
+
+	return fmt.Sprintf(
+		"ERROR: ClusterProfile %q: %s featureset is missing %d featuregate(s) that are enabled in %s:\n  - %s\n\nAll featuregates enabled in %s must also be enabled in %s.",
+		profile,
+		subsetFeatureSet,
+		len(missingList),
+		supersetFeatureSet,
+		strings.Join(missingList, "\n  - "),
+		supersetFeatureSet,
+		subsetFeatureSet,
+	)
+}
+
+// formatConflictingDisableError renders the violation message for a featureset that explicitly
+// disables a featuregate another featureset enables, which is a stronger conflict than simply not
+// mentioning the gate at all.
+func formatConflictingDisableError(profile, enabledInFeatureSet, disabledInFeatureSet string, conflicting sets.String) string {
+	conflictingList := conflicting.List()
+	sort.Strings(conflictingList)
+
+	return fmt.Sprintf(
+		"ERROR: ClusterProfile %q: %s featureset explicitly disables %d featuregate(s) that %s enables:\n  - %s\n\nA featureset must never disable a featuregate that %s enables.",
+		profile,
+		disabledInFeatureSet,
+		len(conflictingList),
+		enabledInFeatureSet,
+		strings.Join(conflictingList, "\n  - "),
+		enabledInFeatureSet,
+	)
+}
+
+// featureGateSet captures the featuregates a manifest explicitly enables and disables for one
+// (ClusterProfile, FeatureSet) tuple, along with the manifest it came from.
+type featureGateSet struct {
+	Enabled      sets.String
+	Disabled     sets.String
+	ManifestPath string
 }
 
 // readFeatureGateManifests reads the featuregate manifests and returns a map of
-// cluster profile -> feature set -> enabled featuregates
-func readFeatureGateManifests(manifestDir string) (map[string]map[string]sets.String, error) {
-	result := map[string]map[string]sets.String{}
+// cluster profile -> feature set -> enabled/disabled featuregates
+func readFeatureGateManifests(manifestDir string) (map[string]map[string]featureGateSet, error) {
+	result := map[string]map[string]featureGateSet{}
 
 	files, err := os.ReadDir(manifestDir)
 	if err != nil {
@@ -150,9 +263,9 @@ func readFeatureGateManifests(manifestDir string) (map[string]map[string]sets.St
 		}
 		uncastObj := unstructured.Unstructured{Object: obj}
 
-		// Get cluster profile from annotations
-		profile := getClusterProfile(uncastObj.GetAnnotations())
-		if profile == "" {
+		// Get cluster profiles from annotations. A manifest can declare more than one.
+		profiles := getClusterProfiles(uncastObj.GetAnnotations())
+		if len(profiles) == 0 {
 			continue // Skip if no profile found
 		}
 
@@ -162,12 +275,14 @@ func readFeatureGateManifests(manifestDir string) (map[string]map[string]sets.St
 			featureSet = "Default"
 		}
 
-		// Get enabled featuregates
+		// Get enabled and disabled featuregates
 		enabledGates := sets.NewString()
+		disabledGates := sets.NewString()
 		featureGateSlice, _, err := unstructured.NestedSlice(obj, "status", "featureGates")
 		if err == nil && len(featureGateSlice) > 0 {
-			enabledList, _, err := unstructured.NestedSlice(featureGateSlice[0].(map[string]interface{}), "enabled")
-			if err == nil {
+			featureGates := featureGateSlice[0].(map[string]interface{})
+
+			if enabledList, _, err := unstructured.NestedSlice(featureGates, "enabled"); err == nil {
 				for _, gate := range enabledList {
 					name, _, _ := unstructured.NestedString(gate.(map[string]interface{}), "name")
 					if name != "" {
@@ -175,30 +290,67 @@ func readFeatureGateManifests(manifestDir string) (map[string]map[string]sets.St
 					}
 				}
 			}
+
+			if disabledList, _, err := unstructured.NestedSlice(featureGates, "disabled"); err == nil {
+				for _, gate := range disabledList {
+					name, _, _ := unstructured.NestedString(gate.(map[string]interface{}), "name")
+					if name != "" {
+						disabledGates.Insert(name)
+					}
+				}
+			}
 		}
 
-		// Store in result
-		if _, ok := result[profile]; !ok {
-			result[profile] = map[string]sets.String{}
+		// Store in result, once per profile the manifest declares
+		for _, profile := range profiles {
+			if _, ok := result[profile]; !ok {
+				result[profile] = map[string]featureGateSet{}
+			}
+			result[profile][featureSet] = featureGateSet{Enabled: enabledGates, Disabled: disabledGates, ManifestPath: filePath}
 		}
-		result[profile][featureSet] = enabledGates
 	}
 
 	return result, nil
 }
 
-// getClusterProfile extracts a simplified cluster profile name from annotations
-func getClusterProfile(annotations map[string]string) string {
+// clusterProfileAnnotationPrefix is the annotation namespace cluster profiles are declared under,
+// e.g. "include.release.openshift.io/self-managed-high-availability".
+const clusterProfileAnnotationPrefix = "include.release.openshift.io/"
+
+// clusterProfileAnnotationTruthyValues are the annotation values that mark a manifest as
+// belonging to the profile named by the annotation's suffix. "false-except-for-the-config-operator"
+// is truthy here: that value includes a manifest only for the config operator, and the featuregate
+// manifests are the config operator's own, so for them it still means included for that profile.
+var clusterProfileAnnotationTruthyValues = sets.NewString("true", "True", "false-except-for-the-config-operator")
+
+// clusterProfileAnnotationSuffixNames maps the two established annotation suffixes to their
+// existing canonical profile names, so this refactor from the hardcoded getClusterProfile doesn't
+// silently rename them everywhere they're used (error messages, --profiles values, manifest
+// lookups). Suffixes with no entry here fall back to the raw annotation suffix, which is how new
+// profiles get picked up without a code change.
+var clusterProfileAnnotationSuffixNames = map[string]string{
+	"self-managed-high-availability": "SelfManagedHA",
+	"ibm-cloud-managed":              "Hypershift",
+}
+
+// getClusterProfiles extracts every ClusterProfile a manifest declares via its
+// "include.release.openshift.io/<profile>" annotations. The two established profiles keep their
+// canonical SelfManagedHA/Hypershift names; any other suffix is used as-is so new profiles are
+// picked up without a code change.
+func getClusterProfiles(annotations map[string]string) []string {
+	profiles := sets.NewString()
 	for k, v := range annotations {
-		if strings.HasPrefix(k, "include.release.openshift.io/") && v == "false-except-for-the-config-operator" {
-			// Extract short name from annotation
-			if strings.Contains(k, "self-managed-high-availability") {
-				return "SelfManagedHA"
-			}
-			if strings.Contains(k, "ibm-cloud-managed") {
-				return "Hypershift"
-			}
+		if !strings.HasPrefix(k, clusterProfileAnnotationPrefix) {
+			continue
+		}
+		if !clusterProfileAnnotationTruthyValues.Has(v) {
+			continue
+		}
+		suffix := strings.TrimPrefix(k, clusterProfileAnnotationPrefix)
+		if name, known := clusterProfileAnnotationSuffixNames[suffix]; known {
+			suffix = name
 		}
+		profiles.Insert(suffix)
 	}
-	return ""
+	return profiles.List()
 }